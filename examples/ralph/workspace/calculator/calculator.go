@@ -1,7 +1,5 @@
 package calculator
 
-import "errors"
-
 // Add returns the sum of two float64 numbers
 func Add(a, b float64) float64 {
     return a + b
@@ -20,7 +18,7 @@ func Multiply(a, b float64) float64 {
 // Divide returns the quotient of two float64 numbers or an error if dividing by zero
 func Divide(a, b float64) (float64, error) {
     if b == 0 {
-        return 0, errors.New("division by zero")
+        return 0, E("divide", KindDivByZero)
     }
     return a / b, nil
 }
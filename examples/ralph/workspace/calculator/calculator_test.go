@@ -1,58 +1,71 @@
 package calculator
 
 import (
+	"errors"
 	"testing"
 )
 
 func TestAdd(t *testing.T) {
 	result := Add(2, 3)
 	if result != 5 {
-		t.Errorf("Expected 5, got %d", result)
+		t.Errorf("Expected 5, got %v", result)
 	}
 
 	result = Add(-2, -3)
 	if result != -5 {
-		t.Errorf("Expected -5, got %d", result)
+		t.Errorf("Expected -5, got %v", result)
 	}
 }
 
 func TestSubtract(t *testing.T) {
 	result := Subtract(5, 3)
 	if result != 2 {
-		t.Errorf("Expected 2, got %d", result)
+		t.Errorf("Expected 2, got %v", result)
 	}
 
 	result = Subtract(-5, -3)
 	if result != -2 {
-		t.Errorf("Expected -2, got %d", result)
+		t.Errorf("Expected -2, got %v", result)
 	}
 }
 
 func TestMultiply(t *testing.T) {
 	result := Multiply(2, 3)
 	if result != 6 {
-		t.Errorf("Expected 6, got %d", result)
+		t.Errorf("Expected 6, got %v", result)
 	}
 
 	result = Multiply(-2, -3)
 	if result != 6 {
-		t.Errorf("Expected 6, got %d", result)
+		t.Errorf("Expected 6, got %v", result)
 	}
 }
 
 func TestDivide(t *testing.T) {
-	result := Divide(6, 3)
+	result, err := Divide(6, 3)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
 	if result != 2 {
-		t.Errorf("Expected 2, got %d", result)
+		t.Errorf("Expected 2, got %v", result)
 	}
 
-	result = Divide(-6, -3)
+	result, err = Divide(-6, -3)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
 	if result != 2 {
-		t.Errorf("Expected 2, got %d", result)
+		t.Errorf("Expected 2, got %v", result)
 	}
 
-	_, err := Divide(1, 0)
+	_, err = Divide(1, 0)
 	if err == nil {
-		t.Error("Expected error when dividing by zero")
+		t.Fatal("Expected error when dividing by zero")
+	}
+	if !errors.Is(err, ErrDivByZero) {
+		t.Errorf("Expected errors.Is(err, ErrDivByZero) to be true, got error: %v", err)
+	}
+	if err.Error() != "calculator: divide: division by zero" {
+		t.Errorf("Expected %q, got %q", "calculator: divide: division by zero", err.Error())
 	}
 }
\ No newline at end of file
@@ -0,0 +1,116 @@
+package calculator
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kind categorizes the cause of an Error so callers can branch on it
+// without parsing the message.
+type Kind int
+
+const (
+	KindDivByZero Kind = iota
+	KindOverflow
+	KindParse
+	KindUndefinedVar
+	KindUndefinedFunc
+	KindArity
+	KindDomain
+)
+
+// String returns the default human-readable description for k, used as the
+// Error message when no more specific detail is available.
+func (k Kind) String() string {
+	switch k {
+	case KindDivByZero:
+		return "division by zero"
+	case KindOverflow:
+		return "overflow"
+	case KindParse:
+		return "parse error"
+	case KindUndefinedVar:
+		return "undefined variable"
+	case KindUndefinedFunc:
+		return "undefined function"
+	case KindArity:
+		return "wrong number of arguments"
+	case KindDomain:
+		return "domain error"
+	default:
+		return "unknown error"
+	}
+}
+
+// Sentinel errors, one per Kind, so callers can write
+// errors.Is(err, calculator.ErrDivByZero) instead of matching on Kind or
+// message text directly.
+var (
+	ErrDivByZero     = errors.New("division by zero")
+	ErrOverflow      = errors.New("overflow")
+	ErrParse         = errors.New("parse error")
+	ErrUndefinedVar  = errors.New("undefined variable")
+	ErrUndefinedFunc = errors.New("undefined function")
+	ErrArity         = errors.New("wrong number of arguments")
+	ErrDomain        = errors.New("domain error")
+)
+
+var sentinelByKind = map[Kind]error{
+	KindDivByZero:     ErrDivByZero,
+	KindOverflow:      ErrOverflow,
+	KindParse:         ErrParse,
+	KindUndefinedVar:  ErrUndefinedVar,
+	KindUndefinedFunc: ErrUndefinedFunc,
+	KindArity:         ErrArity,
+	KindDomain:        ErrDomain,
+}
+
+// Error is returned by calculator's parsing and evaluation functions. Op
+// names the operation that failed (e.g. "divide", "parse", "eval"), Kind
+// categorizes the failure for errors.Is matching, and Pos is the rune
+// offset into the source for parser errors (-1 when not applicable).
+type Error struct {
+	Op   string
+	Kind Kind
+	Pos  int
+	Err  error
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	detail := e.Kind.String()
+	if e.Err != nil {
+		detail = e.Err.Error()
+	}
+	return fmt.Sprintf("calculator: %s: %s", e.Op, detail)
+}
+
+// Unwrap exposes the underlying cause, if any, for errors.Is/As chains.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is the sentinel error for e.Kind, so
+// errors.Is(err, calculator.ErrDivByZero) works regardless of Op or the
+// wrapped detail message.
+func (e *Error) Is(target error) bool {
+	sentinel, ok := sentinelByKind[e.Kind]
+	return ok && target == sentinel
+}
+
+// E constructs a *Error for op/kind. args may include an underlying error
+// or detail string (used as the wrapped cause) and/or an int used as Pos.
+func E(op string, kind Kind, args ...any) error {
+	e := &Error{Op: op, Kind: kind, Pos: -1}
+	for _, a := range args {
+		switch v := a.(type) {
+		case error:
+			e.Err = v
+		case string:
+			e.Err = errors.New(v)
+		case int:
+			e.Pos = v
+		}
+	}
+	return e
+}
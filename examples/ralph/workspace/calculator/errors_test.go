@@ -0,0 +1,36 @@
+package calculator
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestErrorIsMatchesSentinel(t *testing.T) {
+	err := E("divide", KindDivByZero)
+	if !errors.Is(err, ErrDivByZero) {
+		t.Errorf("expected errors.Is(err, ErrDivByZero) to be true")
+	}
+	if errors.Is(err, ErrUndefinedVar) {
+		t.Errorf("expected errors.Is(err, ErrUndefinedVar) to be false")
+	}
+}
+
+func TestErrorUnwrap(t *testing.T) {
+	cause := errors.New("boom")
+	err := E("eval", KindDomain, cause)
+
+	var calcErr *Error
+	if !errors.As(err, &calcErr) {
+		t.Fatalf("expected errors.As to find a *Error")
+	}
+	if !errors.Is(err, cause) {
+		t.Errorf("expected errors.Is(err, cause) to be true via Unwrap")
+	}
+}
+
+func TestErrorMessageFormat(t *testing.T) {
+	err := E("divide", KindDivByZero)
+	if got, want := err.Error(), "calculator: divide: division by zero"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,168 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+)
+
+// Env holds the variable bindings used when evaluating an Expr.
+type Env map[string]float64
+
+// Expr is a parsed arithmetic expression. Use Parse to build one from a
+// string and Eval to evaluate it against a set of variable bindings.
+type Expr interface {
+	// Eval evaluates the expression using env for variable lookups.
+	Eval(env Env) (float64, error)
+	// Check reports whether every variable referenced by the expression is
+	// present in vars, without evaluating it.
+	Check(vars map[string]bool) error
+}
+
+// Literal is a constant numeric value.
+type Literal struct {
+	Value float64
+}
+
+// Eval implements Expr.
+func (l Literal) Eval(env Env) (float64, error) {
+	return l.Value, nil
+}
+
+// Check implements Expr.
+func (l Literal) Check(vars map[string]bool) error {
+	return nil
+}
+
+// Var is a reference to a named variable in the evaluation Env.
+type Var struct {
+	Name string
+}
+
+// Eval implements Expr.
+func (v Var) Eval(env Env) (float64, error) {
+	val, ok := env[v.Name]
+	if !ok {
+		return 0, E("eval", KindUndefinedVar, fmt.Sprintf("undefined variable %q", v.Name))
+	}
+	return val, nil
+}
+
+// Check implements Expr.
+func (v Var) Check(vars map[string]bool) error {
+	if !vars[v.Name] {
+		return E("check", KindUndefinedVar, fmt.Sprintf("undefined variable %q", v.Name))
+	}
+	return nil
+}
+
+// Unary is a prefix +/- applied to another expression.
+type Unary struct {
+	Op string // "+" or "-"
+	X  Expr
+}
+
+// Eval implements Expr.
+func (u Unary) Eval(env Env) (float64, error) {
+	x, err := u.X.Eval(env)
+	if err != nil {
+		return 0, err
+	}
+	switch u.Op {
+	case "-":
+		return -x, nil
+	case "+":
+		return x, nil
+	default:
+		return 0, E("eval", KindDomain, fmt.Sprintf("unknown unary operator %q", u.Op))
+	}
+}
+
+// Check implements Expr.
+func (u Unary) Check(vars map[string]bool) error {
+	return u.X.Check(vars)
+}
+
+// Binary is an infix arithmetic operation on two expressions.
+type Binary struct {
+	Op   string // "+", "-", "*", "/" or "%"
+	X, Y Expr
+}
+
+// Eval implements Expr. Division reuses the package-level Divide helper so
+// the divide-by-zero error is reported the same way as the two-float API.
+func (b Binary) Eval(env Env) (float64, error) {
+	x, err := b.X.Eval(env)
+	if err != nil {
+		return 0, err
+	}
+	y, err := b.Y.Eval(env)
+	if err != nil {
+		return 0, err
+	}
+	switch b.Op {
+	case "+":
+		return Add(x, y), nil
+	case "-":
+		return Subtract(x, y), nil
+	case "*":
+		return Multiply(x, y), nil
+	case "/":
+		return Divide(x, y)
+	case "%":
+		if y == 0 {
+			return 0, E("mod", KindDivByZero)
+		}
+		return math.Mod(x, y), nil
+	default:
+		return 0, E("eval", KindDomain, fmt.Sprintf("unknown binary operator %q", b.Op))
+	}
+}
+
+// Check implements Expr.
+func (b Binary) Check(vars map[string]bool) error {
+	if err := b.X.Check(vars); err != nil {
+		return err
+	}
+	return b.Y.Check(vars)
+}
+
+// Call is a named function applied to zero or more argument expressions.
+// The function itself is looked up in the registerable table maintained by
+// RegisterFunc at evaluation time.
+type Call struct {
+	Func string
+	Args []Expr
+}
+
+// Eval implements Expr.
+func (c Call) Eval(env Env) (float64, error) {
+	fn, ok := funcs[c.Func]
+	if !ok {
+		return 0, E("eval", KindUndefinedFunc, fmt.Sprintf("unknown function %q", c.Func))
+	}
+	args := make([]float64, len(c.Args))
+	for i, a := range c.Args {
+		v, err := a.Eval(env)
+		if err != nil {
+			return 0, err
+		}
+		args[i] = v
+	}
+	if fn.arity >= 0 && len(args) != fn.arity {
+		return 0, E(c.Func, KindArity, fmt.Sprintf("expected %d argument(s), got %d", fn.arity, len(args)))
+	}
+	if fn.arity < 0 && len(args) == 0 {
+		return 0, E(c.Func, KindArity, "expected at least 1 argument, got 0")
+	}
+	return fn.call(args)
+}
+
+// Check implements Expr.
+func (c Call) Check(vars map[string]bool) error {
+	for _, a := range c.Args {
+		if err := a.Check(vars); err != nil {
+			return err
+		}
+	}
+	return nil
+}
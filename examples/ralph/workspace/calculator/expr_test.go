@@ -0,0 +1,125 @@
+package calculator
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParseAndEval(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		env  Env
+		want float64
+	}{
+		{"sqrt of ratio", "sqrt(A / pi)", Env{"A": 28.274333882308138, "pi": math.Pi}, 3},
+		{"sum of cubes", "pow(x, 3) + pow(y, 3)", Env{"x": 2, "y": 3}, 35},
+		{"fahrenheit to celsius", "5 / 9 * (F - 32)", Env{"F": 212}, 100},
+		{"unary minus", "-3 + 5", nil, 2},
+		{"unary plus", "+3 - 5", nil, -2},
+		{"modulo", "7 % 3", nil, 1},
+		{"min and max", "max(1, min(2, 3))", nil, 2},
+		{"nested parens", "2 * (3 + (4 - 1))", nil, 12},
+		{"abs", "abs(-4.5)", nil, 4.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", tt.expr, err)
+			}
+			got, err := expr.Eval(tt.env)
+			if err != nil {
+				t.Fatalf("Eval(%q) returned error: %v", tt.expr, err)
+			}
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Eval(%q) = %v, want %v", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+	}{
+		{"malformed tokens", "1 + * 2"},
+		{"unbalanced parens", "(1 + 2"},
+		{"trailing tokens", "1 2"},
+		{"empty call args", "pow(1,)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.expr)
+			if err == nil {
+				t.Fatalf("Parse(%q) expected an error, got none", tt.expr)
+			}
+			if !errors.Is(err, ErrParse) {
+				t.Errorf("Parse(%q) expected errors.Is(err, ErrParse), got: %v", tt.expr, err)
+			}
+		})
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		env  Env
+		want error
+	}{
+		{"unknown variable", "x + 1", nil, ErrUndefinedVar},
+		{"unknown function", "frobnicate(1)", nil, ErrUndefinedFunc},
+		{"wrong arity", "pow(1)", nil, ErrArity},
+		{"division by zero", "1 / 0", nil, ErrDivByZero},
+		{"domain error", "sqrt(-1)", nil, ErrDomain},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", tt.expr, err)
+			}
+			_, err = expr.Eval(tt.env)
+			if err == nil {
+				t.Fatalf("Eval(%q) expected an error, got none", tt.expr)
+			}
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Eval(%q) expected errors.Is(err, %v), got: %v", tt.expr, tt.want, err)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	expr, err := Parse("pow(x, 2) + y")
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+
+	if err := expr.Check(map[string]bool{"x": true, "y": true}); err != nil {
+		t.Errorf("Check with all variables defined returned error: %v", err)
+	}
+
+	err = expr.Check(map[string]bool{"x": true})
+	if err == nil {
+		t.Fatal("Check with missing variable expected an error, got none")
+	}
+	if !errors.Is(err, ErrUndefinedVar) {
+		t.Errorf("Expected errors.Is(err, ErrUndefinedVar), got: %v", err)
+	}
+}
+
+func TestUnaryBinaryInvalidOpIsDomainError(t *testing.T) {
+	if _, err := (Unary{Op: "!", X: Literal{Value: 1}}).Eval(nil); !errors.Is(err, ErrDomain) {
+		t.Errorf("Expected errors.Is(err, ErrDomain) for an invalid unary operator, got: %v", err)
+	}
+	if _, err := (Binary{Op: "^", X: Literal{Value: 1}, Y: Literal{Value: 2}}).Eval(nil); !errors.Is(err, ErrDomain) {
+		t.Errorf("Expected errors.Is(err, ErrDomain) for an invalid binary operator, got: %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+package calculator
+
+import (
+	"math"
+)
+
+// builtin is a registered function usable from parsed expressions via Call.
+// arity is the required argument count, or -1 for a variadic function that
+// accepts one or more arguments.
+type builtin struct {
+	arity int
+	call  func(args []float64) (float64, error)
+}
+
+// funcs is the table of functions reachable from Call expressions. It is
+// seeded with a handful of common math functions and can be extended with
+// RegisterFunc.
+var funcs = map[string]builtin{
+	"pow": {2, func(args []float64) (float64, error) {
+		return math.Pow(args[0], args[1]), nil
+	}},
+	"sqrt": {1, func(args []float64) (float64, error) {
+		if args[0] < 0 {
+			return 0, E("sqrt", KindDomain, "sqrt of negative number")
+		}
+		return math.Sqrt(args[0]), nil
+	}},
+	"sin": {1, func(args []float64) (float64, error) {
+		return math.Sin(args[0]), nil
+	}},
+	"cos": {1, func(args []float64) (float64, error) {
+		return math.Cos(args[0]), nil
+	}},
+	"abs": {1, func(args []float64) (float64, error) {
+		return math.Abs(args[0]), nil
+	}},
+	"min": {-1, func(args []float64) (float64, error) {
+		m := args[0]
+		for _, a := range args[1:] {
+			m = math.Min(m, a)
+		}
+		return m, nil
+	}},
+	"max": {-1, func(args []float64) (float64, error) {
+		m := args[0]
+		for _, a := range args[1:] {
+			m = math.Max(m, a)
+		}
+		return m, nil
+	}},
+}
+
+// RegisterFunc adds or replaces a named function available to parsed
+// expressions. arity is the required argument count, or -1 to accept a
+// variadic list of one or more arguments.
+func RegisterFunc(name string, arity int, fn func(args []float64) (float64, error)) {
+	funcs[name] = builtin{arity: arity, call: fn}
+}
@@ -0,0 +1,166 @@
+package calculator
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Parse parses an arithmetic expression such as "pow(x, 3) + pow(y, 3)"
+// into an Expr that can be evaluated against an Env.
+func Parse(input string) (Expr, error) {
+	p := &parser{lex: newLexer(input)}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	expr, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokEOF {
+		return nil, E("parse", KindParse, fmt.Sprintf("unexpected token %q at position %d", p.tok.text, p.tok.pos), p.tok.pos)
+	}
+	return expr, nil
+}
+
+// parser is a recursive-descent parser over the lexer's token stream.
+type parser struct {
+	lex *lexer
+	tok token
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+// parseExpr handles the lowest-precedence + and - operators.
+func (p *parser) parseExpr() (Expr, error) {
+	x, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for p.tok.kind == tokOp && (p.tok.text == "+" || p.tok.text == "-") {
+		op := p.tok.text
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		y, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		x = Binary{Op: op, X: x, Y: y}
+	}
+	return x, nil
+}
+
+// parseTerm handles *, / and % which bind tighter than + and -.
+func (p *parser) parseTerm() (Expr, error) {
+	x, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.tok.kind == tokOp && (p.tok.text == "*" || p.tok.text == "/" || p.tok.text == "%") {
+		op := p.tok.text
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		y, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		x = Binary{Op: op, X: x, Y: y}
+	}
+	return x, nil
+}
+
+// parseUnary handles a leading + or - applied to another unary or primary.
+func (p *parser) parseUnary() (Expr, error) {
+	if p.tok.kind == tokOp && (p.tok.text == "+" || p.tok.text == "-") {
+		op := p.tok.text
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return Unary{Op: op, X: x}, nil
+	}
+	return p.parsePrimary()
+}
+
+// parsePrimary handles literals, variables, function calls and
+// parenthesized sub-expressions.
+func (p *parser) parsePrimary() (Expr, error) {
+	switch p.tok.kind {
+	case tokNumber:
+		text := p.tok.text
+		pos := p.tok.pos
+		v, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, E("parse", KindParse, fmt.Sprintf("invalid number %q at position %d", text, pos), pos)
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		return Literal{Value: v}, nil
+
+	case tokIdent:
+		name := p.tok.text
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokLParen {
+			return Var{Name: name}, nil
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		var args []Expr
+		if p.tok.kind != tokRParen {
+			for {
+				arg, err := p.parseExpr()
+				if err != nil {
+					return nil, err
+				}
+				args = append(args, arg)
+				if p.tok.kind != tokComma {
+					break
+				}
+				if err := p.advance(); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if p.tok.kind != tokRParen {
+			return nil, E("parse", KindParse, fmt.Sprintf("expected ')' at position %d", p.tok.pos), p.tok.pos)
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		return Call{Func: name, Args: args}, nil
+
+	case tokLParen:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		x, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokRParen {
+			return nil, E("parse", KindParse, fmt.Sprintf("expected ')' at position %d", p.tok.pos), p.tok.pos)
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		return x, nil
+
+	default:
+		return nil, E("parse", KindParse, fmt.Sprintf("unexpected token %q at position %d", p.tok.text, p.tok.pos), p.tok.pos)
+	}
+}
@@ -1,14 +1,21 @@
 package main
 
 import (
+	"bufio"
 	"fmt"
+	"io"
 	"os"
+	"sort"
+	"strings"
+
+	"adk-rust/examples/ralph/workspace/calculator"
 )
 
 const version = "1.0.0"
-const usage = "Usage: hello-world-cli [--version | --help]"
+const usage = "Usage: hello-world-cli [--version | --help | --repl]"
 
 var exit = os.Exit
+var stdin io.Reader = os.Stdin
 
 func main() {
 	if len(os.Args) > 1 {
@@ -19,11 +26,128 @@ func main() {
 		case "--help":
 			fmt.Println(usage)
 			exit(0)
+		case "--repl":
+			repl(bufio.NewReader(stdin))
 		default:
-			fmt.Fprintln(os.Stderr, "Error: Unknown argument '" + os.Args[1] + "'")
+			fmt.Fprintln(os.Stderr, "Error: Unknown argument '"+os.Args[1]+"'")
 			fmt.Println(usage)
 			exit(1)
 		}
+		return
+	}
+
+	br := bufio.NewReader(stdin)
+	if shouldAutoRepl(br) {
+		repl(br)
+		return
 	}
 	fmt.Println("Hello, World!")
-}
\ No newline at end of file
+}
+
+// shouldAutoRepl reports whether main should drop into REPL mode even
+// without --repl, which happens when stdin isn't a terminal but does have
+// input waiting on it (e.g. `echo "1 + 1" | hello-world-cli`).
+func shouldAutoRepl(br *bufio.Reader) bool {
+	if f, ok := stdin.(*os.File); ok {
+		if info, err := f.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+			return false
+		}
+	}
+	_, err := br.Peek(1)
+	return err == nil
+}
+
+// repl runs an interactive read-eval-print loop, evaluating arithmetic
+// expressions read from r against a persistent calculator.Env. Parse and
+// eval errors are written to stderr without stopping the loop.
+func repl(r *bufio.Reader) {
+	env := calculator.Env{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		switch line {
+		case ":quit":
+			return
+		case ":vars":
+			printVars(env)
+			continue
+		case ":reset":
+			env = calculator.Env{}
+			continue
+		}
+
+		if name, rest, ok := splitAssignment(line); ok {
+			v, err := evalLine(rest, env)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				continue
+			}
+			env[name] = v
+			fmt.Println(v)
+			continue
+		}
+
+		v, err := evalLine(line, env)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			continue
+		}
+		fmt.Println(v)
+	}
+}
+
+func evalLine(input string, env calculator.Env) (float64, error) {
+	expr, err := calculator.Parse(input)
+	if err != nil {
+		return 0, err
+	}
+	return expr.Eval(env)
+}
+
+// splitAssignment splits "name = expr" into name and expr. ok is false if
+// line doesn't look like an assignment.
+func splitAssignment(line string) (name, expr string, ok bool) {
+	idx := strings.Index(line, "=")
+	if idx < 0 {
+		return "", "", false
+	}
+	candidate := strings.TrimSpace(line[:idx])
+	if !isIdent(candidate) {
+		return "", "", false
+	}
+	return candidate, strings.TrimSpace(line[idx+1:]), true
+}
+
+func isIdent(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		switch {
+		case r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z'):
+		case i > 0 && r >= '0' && r <= '9':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func printVars(env calculator.Env) {
+	if len(env) == 0 {
+		fmt.Println("(no variables)")
+		return
+	}
+	names := make([]string, 0, len(env))
+	for name := range env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("%s = %v\n", name, env[name])
+	}
+}
@@ -3,14 +3,17 @@ package main
 import (
 	"io"
 	"os"
+	"strings"
 	"testing"
 )
 
 func TestMainOutput(t *testing.T) {
 	oldArgs := os.Args
 	oldStdout := os.Stdout
+	oldStdin := stdin
 
 	os.Args = []string{"cmd"} // Simulate no arguments
+	stdin = strings.NewReader("")
 	r, w, _ := os.Pipe()
 	os.Stdout = w
 
@@ -20,6 +23,7 @@ func TestMainOutput(t *testing.T) {
 	out, _ := io.ReadAll(r)
 	os.Stdout = oldStdout
 	os.Args = oldArgs
+	stdin = oldStdin
 
 	if string(out) != "Hello, World!\n" {
 		t.Errorf("Expected 'Hello, World!\n', got '%s'", string(out))
@@ -95,8 +99,8 @@ func TestHelpFlag(t *testing.T) {
 	if !exited {
 		t.Error("Expected to exit, but did not")
 	}
-	if string(out) != "Usage: hello-world-cli [--version | --help]\n" {
-		t.Errorf("Expected 'Usage: hello-world-cli [--version | --help]\n', got '%s'", string(out))
+	if string(out) != "Usage: hello-world-cli [--version | --help | --repl]\n" {
+		t.Errorf("Expected 'Usage: hello-world-cli [--version | --help | --repl]\n', got '%s'", string(out))
 	}
 }
 
@@ -107,7 +111,7 @@ func TestInvalidArguments(t *testing.T) {
 	oldExit := exit
 
 	expectedStderr := "Error: Unknown argument '--unknown-arg'\n"
-	expectedStdout := "Usage: hello-world-cli [--version | --help]\n"
+	expectedStdout := "Usage: hello-world-cli [--version | --help | --repl]\n"
 	exitCode := 0
 
 	os.Args = []string{"cmd", "--unknown-arg"}
@@ -157,3 +161,74 @@ func TestInvalidArguments(t *testing.T) {
 		t.Errorf("Expected stdout '%s', got '%s'", expectedStdout, string(out))
 	}
 }
+
+func TestReplMode(t *testing.T) {
+	oldArgs := os.Args
+	oldStdout := os.Stdout
+	oldStderr := os.Stderr
+	oldStdin := stdin
+
+	os.Args = []string{"cmd", "--repl"}
+	stdin = strings.NewReader("x = 2\npow(x, 3) + 1\n:vars\n:reset\n:vars\nx\n:quit\nnot reached\n")
+
+	rout, wout, _ := os.Pipe()
+	os.Stdout = wout
+	rerr, werr, _ := os.Pipe()
+	os.Stderr = werr
+
+	defer func() {
+		os.Stdout = oldStdout
+		os.Stderr = oldStderr
+		os.Args = oldArgs
+		stdin = oldStdin
+	}()
+
+	main()
+
+	wout.Close()
+	werr.Close()
+	out, _ := io.ReadAll(rout)
+	errOut, _ := io.ReadAll(rerr)
+
+	outLines := strings.Split(strings.TrimRight(string(out), "\n"), "\n")
+	wantOutLines := []string{"2", "9", "x = 2", "(no variables)"}
+	if len(outLines) != len(wantOutLines) {
+		t.Fatalf("expected stdout lines %v, got %v", wantOutLines, outLines)
+	}
+	for i, want := range wantOutLines {
+		if outLines[i] != want {
+			t.Errorf("stdout line %d = %q, want %q", i, outLines[i], want)
+		}
+	}
+
+	if !strings.Contains(string(errOut), "undefined variable") {
+		t.Errorf("expected stderr to mention the undefined variable, got %q", string(errOut))
+	}
+}
+
+func TestAutoReplOnPipedInput(t *testing.T) {
+	oldArgs := os.Args
+	oldStdout := os.Stdout
+	oldStdin := stdin
+
+	os.Args = []string{"cmd"}
+	stdin = strings.NewReader("1 + 1\n")
+
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	defer func() {
+		os.Stdout = oldStdout
+		os.Args = oldArgs
+		stdin = oldStdin
+	}()
+
+	main()
+
+	w.Close()
+	out, _ := io.ReadAll(r)
+
+	if string(out) != "2\n" {
+		t.Errorf("expected '2\\n', got %q", string(out))
+	}
+}